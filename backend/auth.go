@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const sessionTTL = 24 * time.Hour
+
+type LoginReq struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type LoginResp struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// bootstrapAdmin ensures an admin account exists on first boot, created from
+// ADMIN_USERNAME / ADMIN_PASSWORD env vars if the admins table is empty.
+func bootstrapAdmin(db *sql.DB) error {
+	username := os.Getenv("ADMIN_USERNAME")
+	password := os.Getenv("ADMIN_PASSWORD")
+	if username == "" || password == "" {
+		log.Println("ADMIN_USERNAME / ADMIN_PASSWORD not set, skipping admin bootstrap")
+		return nil
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT count(*) FROM admins`).Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`
+		INSERT INTO admins (username, password_hash)
+		VALUES ($1, $2)
+		ON CONFLICT (username) DO NOTHING
+	`, username, hash)
+	if err != nil {
+		return err
+	}
+	log.Printf("bootstrapped admin user %q", username)
+	return nil
+}
+
+func newSessionToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func loginHandler(db *sql.DB, w http.ResponseWriter, r *http.Request) {
+	if db == nil {
+		http.Error(w, "DB not configured", http.StatusInternalServerError)
+		return
+	}
+
+	var req LoginReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Username == "" || req.Password == "" {
+		http.Error(w, "bad json or missing credentials", http.StatusBadRequest)
+		return
+	}
+
+	var adminID int
+	var hash string
+	err := db.QueryRowContext(r.Context(), `
+		SELECT id, password_hash FROM admins WHERE username = $1
+	`, req.Username).Scan(&adminID, &hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "invalid credentials", http.StatusUnauthorized)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(req.Password)); err != nil {
+		http.Error(w, "invalid credentials", http.StatusUnauthorized)
+		return
+	}
+
+	token, err := newSessionToken()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	expiresAt := time.Now().Add(sessionTTL)
+
+	_, err = db.ExecContext(r.Context(), `
+		INSERT INTO sessions (token, user_id, expires_at, last_activity)
+		VALUES ($1, $2, $3, NOW())
+	`, token, adminID, expiresAt)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(LoginResp{Token: token, ExpiresAt: expiresAt})
+}
+
+func logoutHandler(db *sql.DB, w http.ResponseWriter, r *http.Request) {
+	if db == nil {
+		http.Error(w, "DB not configured", http.StatusInternalServerError)
+		return
+	}
+
+	token := bearerToken(r)
+	if token == "" {
+		http.Error(w, "missing token", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := db.ExecContext(r.Context(), `DELETE FROM sessions WHERE token = $1`, token); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"ok":true}`))
+}
+
+func bearerToken(r *http.Request) string {
+	h := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if !strings.HasPrefix(h, prefix) {
+		return ""
+	}
+	return strings.TrimPrefix(h, prefix)
+}
+
+// validateSession checks the token against the sessions table and bumps
+// last_activity, returning false when the token is missing, unknown, or
+// expired.
+func validateSession(ctx context.Context, db *sql.DB, token string) bool {
+	if token == "" {
+		return false
+	}
+
+	var expiresAt time.Time
+	err := db.QueryRowContext(ctx, `
+		SELECT expires_at FROM sessions WHERE token = $1
+	`, token).Scan(&expiresAt)
+	if err != nil {
+		return false
+	}
+	if time.Now().After(expiresAt) {
+		return false
+	}
+
+	_, _ = db.ExecContext(ctx, `UPDATE sessions SET last_activity = NOW() WHERE token = $1`, token)
+	return true
+}
+
+// withAuth requires a valid Bearer session token before calling h. It is
+// composed into withJSON for write endpoints.
+func withAuth(db *sql.DB, h func(db *sql.DB, w http.ResponseWriter, r *http.Request)) func(db *sql.DB, w http.ResponseWriter, r *http.Request) {
+	return func(db *sql.DB, w http.ResponseWriter, r *http.Request) {
+		if !validateSession(r.Context(), db, bearerToken(r)) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		h(db, w, r)
+	}
+}
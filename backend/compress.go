@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// gzipBuffer captures a handler's response so withGzip can decide, once the
+// full body and Content-Type are known, whether compression is worthwhile.
+type gzipBuffer struct {
+	http.ResponseWriter
+	status int
+	header http.Header
+	buf    bytes.Buffer
+}
+
+func (b *gzipBuffer) Header() http.Header {
+	return b.header
+}
+
+func (b *gzipBuffer) WriteHeader(status int) {
+	b.status = status
+}
+
+func (b *gzipBuffer) Write(p []byte) (int, error) {
+	return b.buf.Write(p)
+}
+
+// Hijack lets gzipBuffer pass through to the underlying connection so
+// WebSocket upgrades still work if a handler happens to be wrapped in
+// withGzip (buffering the body would otherwise break http.Hijacker).
+func (b *gzipBuffer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := b.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("gzipBuffer: underlying ResponseWriter does not implement http.Hijacker")
+	}
+	return hj.Hijack()
+}
+
+// withGzip negotiates Accept-Encoding: gzip for application/json and text
+// responses at or above minBytes, toggled via GZIP_ENABLED / GZIP_MIN_BYTES.
+func withGzip(next http.Handler) http.Handler {
+	if getenv("GZIP_ENABLED", "true") == "false" {
+		return next
+	}
+	minBytes := 512
+	if v := getenv("GZIP_MIN_BYTES", ""); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			minBytes = n
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isWebSocketUpgrade(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Vary", "Accept-Encoding")
+
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		buf := &gzipBuffer{ResponseWriter: w, header: make(http.Header), status: http.StatusOK}
+		next.ServeHTTP(buf, r)
+
+		for k, v := range buf.header {
+			w.Header()[k] = v
+		}
+
+		body := buf.buf.Bytes()
+		if len(body) < minBytes || !isCompressible(buf.header.Get("Content-Type")) {
+			w.WriteHeader(buf.status)
+			_, _ = w.Write(body)
+			return
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Del("Content-Length")
+		w.WriteHeader(buf.status)
+		gz := gzip.NewWriter(w)
+		_, _ = gz.Write(body)
+		_ = gz.Close()
+	})
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	liveWriteWait  = 10 * time.Second
+	livePingPeriod = 30 * time.Second
+	liveSendBuffer = 16
+)
+
+// LiveEvent is pushed to every subscribed client whenever a write handler
+// mutates match or player state.
+type LiveEvent struct {
+	Type     string      `json:"type"`
+	PeriodID int64       `json:"period_id"`
+	Payload  interface{} `json:"payload"`
+}
+
+type liveClient struct {
+	conn *websocket.Conn
+	send chan LiveEvent
+}
+
+// Hub fans LiveEvents out to every registered client, dropping any client
+// whose send buffer is full rather than blocking the publisher.
+type Hub struct {
+	register   chan *liveClient
+	unregister chan *liveClient
+	broadcast  chan LiveEvent
+	clients    map[*liveClient]bool
+}
+
+func newHub() *Hub {
+	return &Hub{
+		register:   make(chan *liveClient),
+		unregister: make(chan *liveClient),
+		broadcast:  make(chan LiveEvent),
+		clients:    make(map[*liveClient]bool),
+	}
+}
+
+func (h *Hub) run() {
+	for {
+		select {
+		case c := <-h.register:
+			h.clients[c] = true
+		case c := <-h.unregister:
+			if _, ok := h.clients[c]; ok {
+				delete(h.clients, c)
+				close(c.send)
+			}
+		case event := <-h.broadcast:
+			for c := range h.clients {
+				select {
+				case c.send <- event:
+				default:
+					delete(h.clients, c)
+					close(c.send)
+				}
+			}
+		}
+	}
+}
+
+func (h *Hub) publish(event LiveEvent) {
+	h.broadcast <- event
+}
+
+var liveHub = newHub()
+
+var liveUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+func liveHandler(w http.ResponseWriter, r *http.Request) {
+	conn, err := liveUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("live: upgrade failed: %v", err)
+		return
+	}
+
+	client := &liveClient{conn: conn, send: make(chan LiveEvent, liveSendBuffer)}
+	liveHub.register <- client
+
+	go client.readPump()
+	go client.writePump()
+}
+
+// readPump discards incoming messages but keeps the connection alive via
+// pong handling; it unregisters the client once the client disconnects.
+func (c *liveClient) readPump() {
+	defer func() {
+		liveHub.unregister <- c
+		c.conn.Close()
+	}()
+
+	c.conn.SetReadDeadline(time.Now().Add(livePingPeriod + 10*time.Second))
+	c.conn.SetPongHandler(func(string) error {
+		c.conn.SetReadDeadline(time.Now().Add(livePingPeriod + 10*time.Second))
+		return nil
+	})
+
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func (c *liveClient) writePump() {
+	ticker := time.NewTicker(livePingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case event, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(liveWriteWait))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			b, err := json.Marshal(event)
+			if err != nil {
+				log.Printf("live: marshal event: %v", err)
+				continue
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, b); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(liveWriteWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// responseWriter wraps http.ResponseWriter to capture the status code and
+// byte count written, for access logging.
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (rw *responseWriter) WriteHeader(status int) {
+	rw.status = status
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+	n, err := rw.ResponseWriter.Write(b)
+	rw.bytes += n
+	return n, err
+}
+
+// Hijack lets responseWriter pass through to the underlying connection so
+// WebSocket upgrades (gorilla/websocket type-asserts http.Hijacker) still
+// work when a handler happens to be wrapped in withAccessLog.
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("responseWriter: underlying ResponseWriter does not implement http.Hijacker")
+	}
+	return hj.Hijack()
+}
+
+// isWebSocketUpgrade reports whether r is a WebSocket handshake request,
+// which withAccessLog/withGzip pass to the next handler untouched, without
+// wrapping the ResponseWriter.
+func isWebSocketUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+// withWebSocketBypass routes WebSocket handshakes straight to raw, skipping
+// the access-log/gzip wrappers entirely, so the upgrade sees the server's own
+// ResponseWriter rather than a wrapper (withGzip buffers the whole body).
+// Everything else goes through wrapped. The per-wrapper isWebSocketUpgrade
+// checks and the wrappers' Hijack methods stay in place as defense in depth.
+func withWebSocketBypass(raw, wrapped http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isWebSocketUpgrade(r) {
+			raw.ServeHTTP(w, r)
+			return
+		}
+		wrapped.ServeHTTP(w, r)
+	})
+}
+
+// withAccessLog replaces the bare method/path/duration logging with an
+// Apache combined-log-format line, toggled via ACCESS_LOG_FORMAT.
+func withAccessLog(next http.Handler) http.Handler {
+	format := getenv("ACCESS_LOG_FORMAT", "combined")
+	if format == "off" {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isWebSocketUpgrade(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		start := time.Now()
+		rw := &responseWriter{ResponseWriter: w}
+		next.ServeHTTP(rw, r)
+
+		log.Printf("%s - - [%s] \"%s %s %s\" %d %d \"%s\" \"%s\" %d",
+			clientIP(r),
+			start.Format("02/Jan/2006:15:04:05 -0700"),
+			r.Method, r.URL.RequestURI(), r.Proto,
+			rw.status, rw.bytes,
+			refererOrDash(r), userAgentOrDash(r),
+			time.Since(start).Milliseconds(),
+		)
+	})
+}
+
+func refererOrDash(r *http.Request) string {
+	if ref := r.Referer(); ref != "" {
+		return ref
+	}
+	return "-"
+}
+
+func userAgentOrDash(r *http.Request) string {
+	if ua := r.UserAgent(); ua != "" {
+		return ua
+	}
+	return "-"
+}
+
+func isCompressible(contentType string) bool {
+	if strings.HasPrefix(contentType, "application/json") {
+		return true
+	}
+	return strings.HasPrefix(contentType, "text/")
+}
@@ -57,11 +57,7 @@ type Period struct {
 	IsActive  bool       `json:"is_active"`
 }
 
-var (
-	db              *sql.DB
-	cachedPeriod    *Period
-	lastPeriodCheck time.Time
-)
+var db *sql.DB
 
 func main() {
 	port := getenv("PORT", "3000")
@@ -82,6 +78,9 @@ func main() {
 		if err := runInitSQL(db, "./init.sql"); err != nil {
 			log.Fatal("init.sql failed:", err)
 		}
+		if err := bootstrapAdmin(db); err != nil {
+			log.Fatal("admin bootstrap failed:", err)
+		}
 		log.Println("DB ready ✅")
 	}
 
@@ -89,41 +88,63 @@ func main() {
 	fs := http.FileServer(root)
 	handler := withSecurityHeaders(withCacheControl(stripDirListing(root, fs)))
 
+	writeLimiter := rateLimiterFromEnv("RATE_WRITE_RPS", 1, "RATE_BURST", 5)
+	readLimiter := rateLimiterFromEnv("RATE_READ_RPS", 10, "RATE_BURST", 20)
+
+	go liveHub.run()
+
 	mux := http.NewServeMux()
-	mux.Handle("/api/add-match", withJSON(db, addMatchHandler))
-	mux.Handle("/api/delete-match", withJSON(db, deleteMatchHandler))
-	mux.Handle("/api/add-player", withJSON(db, addPlayerHandler))
-	mux.Handle("/api/delete-player", withJSON(db, deletePlayerHandler))
-	mux.Handle("/api/add-team", withJSON(db, addTeamHandler))
-	mux.HandleFunc("/api/list-teams", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/api/live", liveHandler)
+	mux.Handle("/api/login", withRateLimit(writeLimiter, withJSON(db, loginHandler)))
+	mux.Handle("/api/logout", withRateLimit(writeLimiter, withJSON(db, logoutHandler)))
+	mux.Handle("/api/add-match", withRateLimit(writeLimiter, withJSON(db, withAuth(db, addMatchHandler))))
+	mux.Handle("/api/delete-match", withRateLimit(writeLimiter, withJSON(db, withAuth(db, deleteMatchHandler))))
+	mux.Handle("/api/add-player", withRateLimit(writeLimiter, withJSON(db, withAuth(db, addPlayerHandler))))
+	mux.Handle("/api/delete-player", withRateLimit(writeLimiter, withJSON(db, withAuth(db, deletePlayerHandler))))
+	mux.Handle("/api/add-team", withRateLimit(writeLimiter, withJSON(db, withAuth(db, addTeamHandler))))
+	mux.Handle("/api/list-teams", withRateLimit(readLimiter, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 		listTeamsHandler(db, w, r)
-	})
-	mux.HandleFunc("/api/current-period", handleCurrentPeriod)
-	mux.HandleFunc("/api/list-periods", handleListPeriods)
-	mux.HandleFunc("/api/players", func(w http.ResponseWriter, r *http.Request) {
+	})))
+	mux.Handle("/api/current-period", withRateLimit(readLimiter, http.HandlerFunc(handleCurrentPeriod)))
+	mux.Handle("/api/list-periods", withRateLimit(readLimiter, http.HandlerFunc(handleListPeriods)))
+	mux.Handle("/api/players", withRateLimit(readLimiter, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 		listPlayersHandler(db, w, r)
-	})
-	mux.HandleFunc("/api/matches", func(w http.ResponseWriter, r *http.Request) {
+	})))
+	mux.Handle("/api/matches", withRateLimit(readLimiter, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 		listMatchesHandler(db, w, r)
-	})
+	})))
+	mux.Handle("/api/standings", withRateLimit(readLimiter, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		standingsHandler(db, w, r)
+	})))
+	mux.Handle("/api/top-scorers", withRateLimit(readLimiter, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		topScorersHandler(db, w, r)
+	})))
 
 	mux.Handle("/", handler)
 
 	srv := &http.Server{
 		Addr:              ":" + port,
-		Handler:           withLogging(mux),
+		Handler:           withWebSocketBypass(mux, withAccessLog(withGzip(mux))),
 		ReadHeaderTimeout: 5 * time.Second,
 	}
 	log.Printf("Listening on :%s …", port)
@@ -169,8 +190,8 @@ func runInitSQL(db *sql.DB, path string) error {
 // --- Period helpers ---
 
 func GetOrCreateActivePeriod(ctx context.Context, db *sql.DB) (*Period, error) {
-	if cachedPeriod != nil && time.Since(lastPeriodCheck) < time.Minute {
-		return cachedPeriod, nil
+	if p, ok := periodCache.Get(ctx); ok {
+		return p, nil
 	}
 
 	tx, err := db.BeginTx(ctx, nil)
@@ -192,8 +213,7 @@ func GetOrCreateActivePeriod(ctx context.Context, db *sql.DB) (*Period, error) {
 			if err := tx.Commit(); err != nil {
 				return nil, err
 			}
-			cachedPeriod = &p
-			lastPeriodCheck = time.Now()
+			periodCache.Set(ctx, &p, periodCacheTTL)
 			return &p, nil
 		}
 
@@ -222,8 +242,8 @@ func GetOrCreateActivePeriod(ctx context.Context, db *sql.DB) (*Period, error) {
 		return nil, err
 	}
 
-	cachedPeriod = &p
-	lastPeriodCheck = time.Now()
+	periodCache.Invalidate(ctx)
+	periodCache.Set(ctx, &p, periodCacheTTL)
 	return &p, nil
 }
 
@@ -514,6 +534,8 @@ func addTeamHandler(db *sql.DB, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	periodCache.Invalidate(r.Context())
+
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(team)
 }
@@ -571,6 +593,17 @@ func addMatchHandler(db *sql.DB, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	periodCache.Invalidate(ctx)
+
+	liveHub.publish(LiveEvent{
+		Type:     "match.added",
+		PeriodID: period.ID,
+		Payload: map[string]int{
+			"id": matchID, "team1_id": req.Team1ID, "team2_id": req.Team2ID,
+			"score1": req.Score1, "score2": req.Score2,
+		},
+	})
+
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(map[string]int{"id": matchID})
 }
@@ -610,6 +643,12 @@ func deleteMatchHandler(db *sql.DB, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	liveHub.publish(LiveEvent{
+		Type:     "match.deleted",
+		PeriodID: period.ID,
+		Payload:  map[string]int{"id": req.ID},
+	})
+
 	w.Header().Set("Content-Type", "application/json")
 	_, _ = w.Write([]byte(`{"ok":true}`))
 }
@@ -669,6 +708,16 @@ func addPlayerHandler(db *sql.DB, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	periodCache.Invalidate(r.Context())
+
+	liveHub.publish(LiveEvent{
+		Type:     "player.added",
+		PeriodID: period.ID,
+		Payload: map[string]interface{}{
+			"name": req.Name, "team_id": req.TeamID, "goals": req.Goals, "assists": req.Assists,
+		},
+	})
+
 	w.Header().Set("Content-Type", "application/json")
 	_, _ = w.Write([]byte(`{"ok":true}`))
 }
@@ -712,6 +761,12 @@ func deletePlayerHandler(db *sql.DB, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	liveHub.publish(LiveEvent{
+		Type:     "player.deleted",
+		PeriodID: period.ID,
+		Payload:  map[string]interface{}{"name": req.Name, "team_id": req.TeamID},
+	})
+
 	w.Header().Set("Content-Type", "application/json")
 	_, _ = w.Write([]byte(`{"ok":true}`))
 }
@@ -721,7 +776,7 @@ func deletePlayerHandler(db *sql.DB, w http.ResponseWriter, r *http.Request) {
 func withJSON(db *sql.DB, h func(db *sql.DB, w http.ResponseWriter, r *http.Request)) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusNoContent)
 			return
@@ -734,14 +789,6 @@ func withJSON(db *sql.DB, h func(db *sql.DB, w http.ResponseWriter, r *http.Requ
 	})
 }
 
-func withLogging(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-		next.ServeHTTP(w, r)
-		log.Printf("%s %s %s", r.Method, r.URL.Path, time.Since(start))
-	})
-}
-
 func withSecurityHeaders(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("X-Content-Type-Options", "nosniff")
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	periodCacheTTL        = time.Minute
+	periodInvalidateTopic = "periods:invalidate"
+	periodRedisKey        = "period:active"
+)
+
+// PeriodCache caches the active period in memory, guarded by a mutex so
+// concurrent requests don't race on the cached period and its fetch time.
+// When REDIS_URL is set, Get/Set also read
+// and write through to a shared Redis key, so a period populated by one
+// instance is visible to its peers instead of each one re-querying Postgres
+// independently; Invalidate clears both tiers and publishes so peers drop
+// their local copy immediately rather than waiting out the local TTL.
+type PeriodCache struct {
+	mu        sync.RWMutex
+	period    *Period
+	fetchedAt time.Time
+	ttl       time.Duration
+
+	redis *redis.Client
+}
+
+func newPeriodCache(ttl time.Duration) *PeriodCache {
+	pc := &PeriodCache{ttl: ttl}
+
+	if url := os.Getenv("REDIS_URL"); url != "" {
+		opts, err := redis.ParseURL(url)
+		if err != nil {
+			log.Printf("period cache: bad REDIS_URL, running memory-only: %v", err)
+			return pc
+		}
+		pc.redis = redis.NewClient(opts)
+		go pc.subscribeInvalidations()
+	}
+
+	return pc
+}
+
+func (pc *PeriodCache) subscribeInvalidations() {
+	ctx := context.Background()
+	sub := pc.redis.Subscribe(ctx, periodInvalidateTopic)
+	defer sub.Close()
+
+	for range sub.Channel() {
+		pc.invalidateLocal()
+	}
+}
+
+// Get returns the cached period if present and not yet expired. It checks
+// the in-memory tier first; on a local miss, and when Redis is configured,
+// it reads through to Redis so a period populated by a peer instance is
+// shared instead of forcing every instance back to Postgres.
+func (pc *PeriodCache) Get(ctx context.Context) (*Period, bool) {
+	if p, ok := pc.getLocal(); ok {
+		return p, true
+	}
+
+	if pc.redis == nil {
+		return nil, false
+	}
+
+	raw, err := pc.redis.Get(ctx, periodRedisKey).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			log.Printf("period cache: redis get: %v", err)
+		}
+		return nil, false
+	}
+
+	var p Period
+	if err := json.Unmarshal(raw, &p); err != nil {
+		log.Printf("period cache: unmarshal cached period: %v", err)
+		return nil, false
+	}
+
+	pc.setLocal(&p, pc.ttl)
+	return &p, true
+}
+
+func (pc *PeriodCache) getLocal() (*Period, bool) {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+
+	if pc.period == nil || time.Since(pc.fetchedAt) >= pc.ttl {
+		return nil, false
+	}
+	return pc.period, true
+}
+
+func (pc *PeriodCache) setLocal(period *Period, ttl time.Duration) {
+	pc.mu.Lock()
+	pc.period = period
+	pc.fetchedAt = time.Now()
+	pc.ttl = ttl
+	pc.mu.Unlock()
+}
+
+// Set stores period in the in-memory tier, and writes it through to Redis
+// (when configured) so peer instances observe it too.
+func (pc *PeriodCache) Set(ctx context.Context, period *Period, ttl time.Duration) {
+	pc.setLocal(period, ttl)
+
+	if pc.redis == nil {
+		return
+	}
+
+	raw, err := json.Marshal(period)
+	if err != nil {
+		log.Printf("period cache: marshal period: %v", err)
+		return
+	}
+	if err := pc.redis.Set(ctx, periodRedisKey, raw, ttl).Err(); err != nil {
+		log.Printf("period cache: redis set: %v", err)
+	}
+}
+
+// Invalidate drops the local copy and, when Redis is configured, deletes the
+// shared entry and notifies peer instances to drop their local copies too.
+func (pc *PeriodCache) Invalidate(ctx context.Context) {
+	pc.invalidateLocal()
+
+	if pc.redis == nil {
+		return
+	}
+
+	if err := pc.redis.Del(ctx, periodRedisKey).Err(); err != nil {
+		log.Printf("period cache: redis del: %v", err)
+	}
+	if err := pc.redis.Publish(ctx, periodInvalidateTopic, "1").Err(); err != nil {
+		log.Printf("period cache: publish invalidate: %v", err)
+	}
+}
+
+func (pc *PeriodCache) invalidateLocal() {
+	pc.mu.Lock()
+	pc.period = nil
+	pc.fetchedAt = time.Time{}
+	pc.mu.Unlock()
+}
+
+var periodCache = newPeriodCache(periodCacheTTL)
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const visitorIdleTimeout = 10 * time.Minute
+
+type visitor struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// visitorLimiter hands out one rate.Limiter per remote IP, evicting entries
+// that have gone idle.
+type visitorLimiter struct {
+	mu       sync.Mutex
+	visitors map[string]*visitor
+	rps      rate.Limit
+	burst    int
+}
+
+func newVisitorLimiter(rps float64, burst int) *visitorLimiter {
+	vl := &visitorLimiter{
+		visitors: make(map[string]*visitor),
+		rps:      rate.Limit(rps),
+		burst:    burst,
+	}
+	go vl.evictLoop()
+	return vl
+}
+
+func (vl *visitorLimiter) get(ip string) *rate.Limiter {
+	vl.mu.Lock()
+	defer vl.mu.Unlock()
+
+	v, ok := vl.visitors[ip]
+	if !ok {
+		v = &visitor{limiter: rate.NewLimiter(vl.rps, vl.burst)}
+		vl.visitors[ip] = v
+	}
+	v.lastSeen = time.Now()
+	return v.limiter
+}
+
+func (vl *visitorLimiter) evictLoop() {
+	for range time.Tick(time.Minute) {
+		vl.mu.Lock()
+		for ip, v := range vl.visitors {
+			if time.Since(v.lastSeen) > visitorIdleTimeout {
+				delete(vl.visitors, ip)
+			}
+		}
+		vl.mu.Unlock()
+	}
+}
+
+func rateLimiterFromEnv(rpsVar string, defaultRPS float64, burstVar string, defaultBurst int) *visitorLimiter {
+	rps := defaultRPS
+	if v := getenv(rpsVar, ""); v != "" {
+		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+			rps = parsed
+		}
+	}
+	burst := defaultBurst
+	if v := getenv(burstVar, ""); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil {
+			burst = parsed
+		}
+	}
+	return newVisitorLimiter(rps, burst)
+}
+
+// withRateLimit enforces vl's per-IP limit, responding 429 with a
+// Retry-After header computed from the limiter's reservation delay.
+func withRateLimit(vl *visitorLimiter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limiter := vl.get(clientIP(r))
+		res := limiter.Reserve()
+		if !res.OK() {
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		if delay := res.Delay(); delay > 0 {
+			res.Cancel()
+			w.Header().Set("Retry-After", strconv.Itoa(int(delay.Seconds()+1)))
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientIP returns the right-most X-Forwarded-For entry when present. This
+// service sits behind exactly one trusted hop (Railway's edge proxy), which
+// appends the IP it observed rather than replacing the header, so the
+// right-most entry is the only one a client can't forge; everything to its
+// left is attacker-controlled and must not be trusted for rate limiting or
+// access logging. Falls back to RemoteAddr when the header is absent.
+func clientIP(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		parts := strings.Split(fwd, ",")
+		return strings.TrimSpace(parts[len(parts)-1])
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+const formLength = 5
+
+// TeamStanding is one row of the computed league table for a period.
+type TeamStanding struct {
+	TeamID         int    `json:"team_id"`
+	TeamName       string `json:"team_name"`
+	Played         int    `json:"played"`
+	Wins           int    `json:"wins"`
+	Draws          int    `json:"draws"`
+	Losses         int    `json:"losses"`
+	GoalsFor       int    `json:"goals_for"`
+	GoalsAgainst   int    `json:"goals_against"`
+	GoalDifference int    `json:"goal_difference"`
+	Points         int    `json:"points"`
+	Form           string `json:"form"`
+}
+
+type scorerRow struct {
+	Rank     int    `json:"rank"`
+	Name     string `json:"name"`
+	TeamID   int    `json:"team_id"`
+	TeamName string `json:"team_name"`
+	Goals    int    `json:"goals"`
+	Assists  int    `json:"assists"`
+}
+
+func standingsHandler(db *sql.DB, w http.ResponseWriter, r *http.Request) {
+	if db == nil {
+		http.Error(w, "DB not configured", http.StatusInternalServerError)
+		return
+	}
+
+	period, err := periodFromRequest(r.Context(), db, r)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, sql.ErrNoRows) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	teamRows, err := db.QueryContext(r.Context(), `
+		SELECT id, name
+		FROM teams
+		WHERE period_id = $1
+		ORDER BY name
+	`, period.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer teamRows.Close()
+
+	standings := map[int]*TeamStanding{}
+	order := []int{}
+	for teamRows.Next() {
+		var id int
+		var name string
+		if err := teamRows.Scan(&id, &name); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		standings[id] = &TeamStanding{TeamID: id, TeamName: name}
+		order = append(order, id)
+	}
+
+	matchRows, err := db.QueryContext(r.Context(), `
+		SELECT team1_id, team2_id, score1, score2
+		FROM matches
+		WHERE period_id = $1
+		ORDER BY played_at ASC
+	`, period.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer matchRows.Close()
+
+	form := map[int][]byte{}
+	for matchRows.Next() {
+		var t1, t2, s1, s2 int
+		if err := matchRows.Scan(&t1, &t2, &s1, &s2); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		applyMatchToStanding(standings[t1], s1, s2, form)
+		applyMatchToStanding(standings[t2], s2, s1, form)
+		recordForm(form, t1, t2, s1, s2)
+	}
+
+	out := make([]TeamStanding, 0, len(order))
+	for _, id := range order {
+		s := standings[id]
+		s.Form = lastNResults(form[id], formLength)
+		out = append(out, *s)
+	}
+	sortStandings(out)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+func applyMatchToStanding(s *TeamStanding, goalsFor, goalsAgainst int, form map[int][]byte) {
+	if s == nil {
+		return
+	}
+	s.Played++
+	s.GoalsFor += goalsFor
+	s.GoalsAgainst += goalsAgainst
+	s.GoalDifference = s.GoalsFor - s.GoalsAgainst
+	switch {
+	case goalsFor > goalsAgainst:
+		s.Wins++
+		s.Points += 3
+	case goalsFor == goalsAgainst:
+		s.Draws++
+		s.Points++
+	default:
+		s.Losses++
+	}
+}
+
+// recordForm appends the W/D/L result of one match to each team's form
+// history, in the order matches are scanned (i.e. played_at ASC).
+func recordForm(form map[int][]byte, team1, team2, score1, score2 int) {
+	switch {
+	case score1 > score2:
+		form[team1] = append(form[team1], 'W')
+		form[team2] = append(form[team2], 'L')
+	case score1 < score2:
+		form[team1] = append(form[team1], 'L')
+		form[team2] = append(form[team2], 'W')
+	default:
+		form[team1] = append(form[team1], 'D')
+		form[team2] = append(form[team2], 'D')
+	}
+}
+
+func lastNResults(results []byte, n int) string {
+	if len(results) > n {
+		results = results[len(results)-n:]
+	}
+	return string(results)
+}
+
+func sortStandings(s []TeamStanding) {
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && standingLess(s[j], s[j-1]); j-- {
+			s[j], s[j-1] = s[j-1], s[j]
+		}
+	}
+}
+
+func standingLess(a, b TeamStanding) bool {
+	if a.Points != b.Points {
+		return a.Points > b.Points
+	}
+	if a.GoalDifference != b.GoalDifference {
+		return a.GoalDifference > b.GoalDifference
+	}
+	if a.GoalsFor != b.GoalsFor {
+		return a.GoalsFor > b.GoalsFor
+	}
+	return a.TeamName < b.TeamName
+}
+
+func topScorersHandler(db *sql.DB, w http.ResponseWriter, r *http.Request) {
+	if db == nil {
+		http.Error(w, "DB not configured", http.StatusInternalServerError)
+		return
+	}
+
+	period, err := periodFromRequest(r.Context(), db, r)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, sql.ErrNoRows) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	limit := 10
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	rows, err := db.QueryContext(r.Context(), `
+		SELECT p.name, p.team_id, t.name, p.goals, p.assists
+		FROM players p
+		JOIN teams t ON p.team_id = t.id
+		WHERE t.period_id = $1
+		ORDER BY p.goals DESC, p.assists DESC, p.name
+		LIMIT $2
+	`, period.ID, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	out := []scorerRow{}
+	rank := 0
+	for rows.Next() {
+		rank++
+		var s scorerRow
+		if err := rows.Scan(&s.Name, &s.TeamID, &s.TeamName, &s.Goals, &s.Assists); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		s.Rank = rank
+		out = append(out, s)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(out)
+}